@@ -0,0 +1,45 @@
+package customrunserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/tedsuo/rata"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// GetCustomRun serves GET /api/v1/custom-runs/:custom_run_id. Controllers
+// use it to confirm the details of a run before acting on it.
+func (s *Server) GetCustomRun() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-custom-run")
+
+		runID := rata.Param(r, "custom_run_id")
+
+		run, found, err := s.customRunFactory.Lookup(runID)
+		if err != nil {
+			logger.Error("failed-to-lookup-custom-run", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(present(run))
+	})
+}
+
+func present(run db.CustomRun) atc.CustomRun {
+	return atc.CustomRun{
+		ID:      run.ID(),
+		BuildID: run.BuildID(),
+		Type:    run.Type(),
+		Status:  string(run.Status()),
+	}
+}
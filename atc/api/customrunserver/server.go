@@ -0,0 +1,28 @@
+// Package customrunserver implements the HTTP handlers that let an external
+// CustomExecutor controller look up a db.CustomRun and report its progress
+// back to the ATC (see scheduler.CustomExecutorResolver). The handlers are
+// wired into the ATC's main handler factory under the atc.GetCustomRun and
+// atc.ReportCustomRunStatus routes.
+package customrunserver
+
+import (
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	customRunFactory db.CustomRunFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	customRunFactory db.CustomRunFactory,
+) *Server {
+	return &Server{
+		logger:           logger,
+		customRunFactory: customRunFactory,
+	}
+}
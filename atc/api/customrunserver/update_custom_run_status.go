@@ -0,0 +1,53 @@
+package customrunserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/tedsuo/rata"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type updateCustomRunStatusRequest struct {
+	Status db.CustomRunStatus `json:"status"`
+}
+
+// UpdateCustomRunStatus serves PUT /api/v1/custom-runs/:custom_run_id/status.
+// External controllers call this as a run progresses; once it reaches a
+// terminal status, the scheduler picks it up and feeds it into Build.Finish.
+func (s *Server) UpdateCustomRunStatus() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("update-custom-run-status")
+
+		runID := rata.Param(r, "custom_run_id")
+
+		run, found, err := s.customRunFactory.Lookup(runID)
+		if err != nil {
+			logger.Error("failed-to-lookup-custom-run", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var req updateCustomRunStatusRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			logger.Info("malformed-request-body", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if err := run.UpdateStatus(req.Status); err != nil {
+			logger.Error("failed-to-update-custom-run-status", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/tedsuo/rata"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/customrunserver"
+)
+
+// NewCustomRunHandler wires customrunserver's handlers up against the
+// atc.GetCustomRun / atc.ReportCustomRunStatus routes. The result is merged
+// into the ATC's full handler set alongside the handlers for every other
+// resource.
+func NewCustomRunHandler(server *customrunserver.Server) (http.Handler, error) {
+	return rata.NewRouter(atc.Routes, rata.Handlers{
+		atc.GetCustomRun:          server.GetCustomRun(),
+		atc.ReportCustomRunStatus: server.UpdateCustomRunStatus(),
+	})
+}
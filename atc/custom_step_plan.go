@@ -0,0 +1,31 @@
+package atc
+
+// Route names for the custom-run API, wired into atc.Routes alongside the
+// rest of the API in routes.go.
+const (
+	GetCustomRun          = "GetCustomRun"
+	ReportCustomRunStatus = "ReportCustomRunStatus"
+)
+
+// CustomStepPlan is a plan node for a step whose execution is delegated to
+// an external controller rather than run by the ATC itself, via a
+// registered scheduler.CustomExecutor.
+type CustomStepPlan struct {
+	Name string `json:"name"`
+
+	// Type identifies which registered CustomExecutor should handle the
+	// step, e.g. "tekton" or "approval-gate".
+	Type string `json:"type"`
+
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// CustomRun is the API representation of a db.CustomRun. It is returned by
+// GET /api/v1/custom-runs/:id and updated via PUT /api/v1/custom-runs/:id/status
+// as the external controller makes progress.
+type CustomRun struct {
+	ID      int    `json:"id"`
+	BuildID int    `json:"build_id"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+}
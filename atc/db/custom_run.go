@@ -0,0 +1,64 @@
+package db
+
+import "github.com/concourse/concourse/atc"
+
+// CustomRunStatus represents the lifecycle of a build step that has been
+// handed off to an external controller via a scheduler.CustomExecutor.
+type CustomRunStatus string
+
+const (
+	CustomRunStatusAwaitingExternal CustomRunStatus = "awaiting_external"
+	CustomRunStatusSucceeded        CustomRunStatus = "succeeded"
+	CustomRunStatusFailed           CustomRunStatus = "failed"
+	CustomRunStatusTimedOut         CustomRunStatus = "timed_out"
+)
+
+// Finished returns true once the run has reached a terminal status.
+func (status CustomRunStatus) Finished() bool {
+	switch status {
+	case CustomRunStatusSucceeded, CustomRunStatusFailed, CustomRunStatusTimedOut:
+		return true
+	default:
+		return false
+	}
+}
+
+//go:generate counterfeiter . CustomRun
+
+// CustomRun tracks a single step within a build that was delegated to an
+// external controller rather than run as an ordinary task. The ATC never
+// executes the step itself; it persists the reference so that whichever
+// controller claimed it can look it up and report back on its progress,
+// which feeds into Build.Finish once the run reaches a terminal status.
+type CustomRun interface {
+	ID() int
+	BuildID() int
+	PlanID() atc.PlanID
+
+	Type() string
+	Params() map[string]string
+
+	Status() CustomRunStatus
+	UpdateStatus(CustomRunStatus) error
+}
+
+//go:generate counterfeiter . CustomRunFactory
+
+// CustomRunFactory creates and looks up CustomRuns.
+type CustomRunFactory interface {
+	// Create persists a new CustomRun for a build's CustomStepPlan, in
+	// CustomRunStatusAwaitingExternal, so the returned CustomRun can be
+	// handed off to a scheduler.CustomExecutor.
+	Create(buildID int, planID atc.PlanID, stepType string, params map[string]string) (CustomRun, error)
+
+	// Lookup finds a previously-created CustomRun, e.g. for a controller
+	// reporting status back to the API.
+	Lookup(id string) (CustomRun, bool, error)
+
+	// ListTerminated returns every CustomRun whose status has reached a
+	// terminal value, for scheduler.CustomRunPoller to reconcile against the
+	// build it belongs to. Build.Finish is expected to no-op on a build
+	// that isn't in db.BuildStatusStarted, so polling the same run more
+	// than once before its build is reconciled is harmless.
+	ListTerminated() ([]CustomRun, error)
+}
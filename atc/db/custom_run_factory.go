@@ -0,0 +1,169 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// NewCustomRunFactory constructs the production CustomRunFactory, backed by
+// the custom_runs table.
+func NewCustomRunFactory(conn Conn) CustomRunFactory {
+	return &customRunFactory{conn: conn}
+}
+
+type customRunFactory struct {
+	conn Conn
+}
+
+func (factory *customRunFactory) Create(buildID int, planID atc.PlanID, stepType string, params map[string]string) (CustomRun, error) {
+	paramsPayload, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &customRun{
+		conn:    factory.conn,
+		buildID: buildID,
+		planID:  planID,
+		typ:     stepType,
+		params:  params,
+		status:  CustomRunStatusAwaitingExternal,
+	}
+
+	err = psql.Insert("custom_runs").
+		Columns("build_id", "plan_id", "type", "params", "status").
+		Values(buildID, string(planID), stepType, paramsPayload, string(run.status)).
+		Suffix("RETURNING id").
+		RunWith(factory.conn).
+		QueryRow().
+		Scan(&run.id)
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (factory *customRunFactory) Lookup(id string) (CustomRun, bool, error) {
+	runID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	run := &customRun{conn: factory.conn}
+
+	var paramsPayload []byte
+	var planID string
+	var status string
+
+	err = psql.Select("id", "build_id", "plan_id", "type", "params", "status").
+		From("custom_runs").
+		Where(sq.Eq{"id": runID}).
+		RunWith(factory.conn).
+		QueryRow().
+		Scan(&run.id, &run.buildID, &planID, &run.typ, &paramsPayload, &status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	run.planID = atc.PlanID(planID)
+	run.status = CustomRunStatus(status)
+
+	if len(paramsPayload) > 0 {
+		if err := json.Unmarshal(paramsPayload, &run.params); err != nil {
+			return nil, false, err
+		}
+	}
+
+	return run, true, nil
+}
+
+func (factory *customRunFactory) ListTerminated() ([]CustomRun, error) {
+	rows, err := psql.Select("id", "build_id", "plan_id", "type", "params", "status").
+		From("custom_runs").
+		Where(sq.Eq{"status": []string{
+			string(CustomRunStatusSucceeded),
+			string(CustomRunStatusFailed),
+			string(CustomRunStatusTimedOut),
+		}}).
+		RunWith(factory.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var runs []CustomRun
+
+	for rows.Next() {
+		run := &customRun{conn: factory.conn}
+
+		var paramsPayload []byte
+		var planID string
+		var status string
+
+		if err := rows.Scan(&run.id, &run.buildID, &planID, &run.typ, &paramsPayload, &status); err != nil {
+			return nil, err
+		}
+
+		run.planID = atc.PlanID(planID)
+		run.status = CustomRunStatus(status)
+
+		if len(paramsPayload) > 0 {
+			if err := json.Unmarshal(paramsPayload, &run.params); err != nil {
+				return nil, err
+			}
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
+
+// customRun is the production CustomRun, backed by a row in the custom_runs
+// table.
+type customRun struct {
+	conn Conn
+
+	id      int
+	buildID int
+	planID  atc.PlanID
+	typ     string
+	params  map[string]string
+	status  CustomRunStatus
+}
+
+func (run *customRun) ID() int                   { return run.id }
+func (run *customRun) BuildID() int              { return run.buildID }
+func (run *customRun) PlanID() atc.PlanID        { return run.planID }
+func (run *customRun) Type() string              { return run.typ }
+func (run *customRun) Params() map[string]string { return run.params }
+func (run *customRun) Status() CustomRunStatus   { return run.status }
+
+// UpdateStatus persists the new status and, once it's terminal, is what lets
+// the scheduler's polling loop feed the result into Build.Finish.
+func (run *customRun) UpdateStatus(status CustomRunStatus) error {
+	_, err := psql.Update("custom_runs").
+		Set("status", string(status)).
+		Where(sq.Eq{"id": run.id}).
+		RunWith(run.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	run.status = status
+
+	return nil
+}
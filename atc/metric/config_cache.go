@@ -0,0 +1,9 @@
+package metric
+
+// ConfigCacheHits and ConfigCacheMisses track exec.CachingConfigSource's hit
+// rate, so operators can tune ConfigCache sizing under high-throughput
+// scheduling loads.
+var (
+	ConfigCacheHits   = Counter{}
+	ConfigCacheMisses = Counter{}
+)
@@ -0,0 +1,49 @@
+package atc
+
+// PlanID uniquely identifies a node within a build's plan tree.
+type PlanID string
+
+// Plan is a node in a build's plan tree. Exactly one of its step fields is
+// set, identifying what kind of step it represents; composite steps (e.g.
+// OnSuccess, Do) hold further Plans as children.
+type Plan struct {
+	ID PlanID `json:"id"`
+
+	Task   *TaskStepPlan   `json:"task,omitempty"`
+	Custom *CustomStepPlan `json:"custom,omitempty"`
+
+	OnSuccess *OnSuccessPlan `json:"on_success,omitempty"`
+	Do        *DoPlan        `json:"do,omitempty"`
+}
+
+// TaskStepPlan is a plan node for a Task step.
+type TaskStepPlan struct {
+	Name   string      `json:"name"`
+	Config *TaskConfig `json:"config,omitempty"`
+}
+
+// OnSuccessPlan runs Next once Step completes successfully.
+type OnSuccessPlan struct {
+	Step Plan `json:"step"`
+	Next Plan `json:"next"`
+}
+
+// DoPlan runs each of its Plans in sequence.
+type DoPlan []Plan
+
+// Each calls f once for every Plan in the tree rooted at plan, including
+// plan itself, descending into composite steps.
+func (plan Plan) Each(f func(Plan)) {
+	f(plan)
+
+	if plan.OnSuccess != nil {
+		plan.OnSuccess.Step.Each(f)
+		plan.OnSuccess.Next.Each(f)
+	}
+
+	if plan.Do != nil {
+		for _, p := range *plan.Do {
+			p.Each(f)
+		}
+	}
+}
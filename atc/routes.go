@@ -0,0 +1,22 @@
+package atc
+
+import "github.com/tedsuo/rata"
+
+const (
+	ListResourceVersions  = "ListResourceVersions"
+	EnableResourceVersion = "EnableResourceVersion"
+	GetJobBuild           = "GetJobBuild"
+	BuildPlan             = "BuildPlan"
+)
+
+// Routes is the full table of ATC API routes, by name. The API's handler
+// factory wires a http.Handler up against each of these.
+var Routes = rata.Routes{
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_config_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
+	{Path: "/api/v1/builds/:build_id/plan", Method: "GET", Name: BuildPlan},
+
+	{Path: "/api/v1/custom-runs/:custom_run_id", Method: "GET", Name: GetCustomRun},
+	{Path: "/api/v1/custom-runs/:custom_run_id/status", Method: "PUT", Name: ReportCustomRunStatus},
+}
@@ -27,6 +27,10 @@ type BuildStarter interface {
 //go:generate counterfeiter . BuildFactory
 
 type BuildFactory interface {
+	// Create builds the atc.Plan for a build of the given job. Any task steps
+	// it produces carry the atc.ConfigSourceProvenance of the TaskConfigSource
+	// that resolved them, so it ends up recorded on the build's plan and can
+	// be queried back out via atc.Plan.TaskConfigProvenance.
 	Create(atc.JobConfig, atc.ResourceConfigs, atc.VersionedResourceTypes, []db.BuildInput) (atc.Plan, error)
 }
 
@@ -35,21 +39,30 @@ type Build interface {
 
 	IsReadyToDetermineInputs(lager.Logger) bool
 	BuildInputs(context.Context) ([]db.BuildInput, bool, error)
+
+	// SaveCustomRun persists customStep as a db.CustomRun via
+	// db.CustomRunFactory.Create, so it can be handed off to a
+	// scheduler.CustomExecutor and later looked up by an external
+	// controller reporting its progress back to the API.
+	SaveCustomRun(atc.CustomStepPlan) (db.CustomRun, error)
 }
 
 func NewBuildStarter(
 	factory BuildFactory,
 	algorithm Algorithm,
+	customExecutors CustomExecutorResolver,
 ) BuildStarter {
 	return &buildStarter{
-		factory:   factory,
-		algorithm: algorithm,
+		factory:         factory,
+		algorithm:       algorithm,
+		customExecutors: customExecutors,
 	}
 }
 
 type buildStarter struct {
-	factory   BuildFactory
-	algorithm Algorithm
+	factory         BuildFactory
+	algorithm       Algorithm
+	customExecutors CustomExecutorResolver
 }
 
 func (s *buildStarter) TryStartPendingBuildsForJob(
@@ -250,6 +263,10 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return startResults{}, nil
 	}
 
+	if customStep, found := findCustomStep(plan); found {
+		return s.startCustomRun(logger, nextPendingBuild, plan, customStep, scheduled, readyToDetermineInputs)
+	}
+
 	started, err := nextPendingBuild.Start(plan)
 	if err != nil {
 		logger.Error("failed-to-mark-build-as-started", err)
@@ -275,3 +292,90 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		readyToDetermineInputs: readyToDetermineInputs,
 	}, nil
 }
+
+// findCustomStep looks for a step in the plan that was built from a custom
+// executor type rather than an ordinary task, get, or put. Only the first
+// one encountered is reported; a plan with more than one is not currently
+// supported.
+func findCustomStep(plan atc.Plan) (atc.CustomStepPlan, bool) {
+	var customStep atc.CustomStepPlan
+	var found bool
+
+	plan.Each(func(p atc.Plan) {
+		if p.Custom != nil && !found {
+			customStep = *p.Custom
+			found = true
+		}
+	})
+
+	return customStep, found
+}
+
+// startCustomRun marks the build started, persists the custom step as a
+// db.CustomRun, and hands it off to whichever CustomExecutor is registered
+// for its step type. The build is left in "started" state, awaiting the
+// external controller to report a terminal status back via the API, which
+// feeds into Build.Finish.
+func (s *buildStarter) startCustomRun(
+	logger lager.Logger,
+	build Build,
+	plan atc.Plan,
+	customStep atc.CustomStepPlan,
+	scheduled bool,
+	readyToDetermineInputs bool,
+) (startResults, error) {
+	logger = logger.Session("start-custom-run", lager.Data{"custom-executor-type": customStep.Type})
+
+	executor, found := s.customExecutors.Resolve(customStep.Type)
+	if !found {
+		logger.Error("no-custom-executor-registered", fmt.Errorf("unknown custom executor type: %s", customStep.Type))
+
+		if err := build.Finish(db.BuildStatusErrored); err != nil {
+			return startResults{}, fmt.Errorf("finish build: %w", err)
+		}
+
+		return startResults{scheduled: scheduled, readyToDetermineInputs: readyToDetermineInputs}, nil
+	}
+
+	started, err := build.Start(plan)
+	if err != nil {
+		logger.Error("failed-to-mark-build-as-started", err)
+		return startResults{}, fmt.Errorf("start build: %w", err)
+	}
+
+	if !started {
+		if err := build.Finish(db.BuildStatusAborted); err != nil {
+			logger.Error("failed-to-mark-build-as-finished", err)
+			return startResults{}, fmt.Errorf("finish build: %w", err)
+		}
+
+		return startResults{scheduled: scheduled, readyToDetermineInputs: readyToDetermineInputs}, nil
+	}
+
+	run, err := build.SaveCustomRun(customStep)
+	if err != nil {
+		logger.Error("failed-to-save-custom-run", err)
+
+		if finishErr := build.Finish(db.BuildStatusErrored); finishErr != nil {
+			logger.Error("failed-to-mark-build-as-errored", finishErr)
+			return startResults{}, fmt.Errorf("finish build: %w", finishErr)
+		}
+
+		return startResults{}, fmt.Errorf("save custom run: %w", err)
+	}
+
+	if err := executor.Submit(logger, run); err != nil {
+		logger.Error("failed-to-submit-custom-run", err)
+
+		if finishErr := build.Finish(db.BuildStatusErrored); finishErr != nil {
+			logger.Error("failed-to-mark-build-as-errored", finishErr)
+			return startResults{}, fmt.Errorf("finish build: %w", finishErr)
+		}
+
+		return startResults{}, fmt.Errorf("submit custom run: %w", err)
+	}
+
+	metric.BuildsStarted.Inc()
+
+	return startResults{scheduled: scheduled, readyToDetermineInputs: readyToDetermineInputs}, nil
+}
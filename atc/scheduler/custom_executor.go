@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . CustomExecutor
+
+// CustomExecutor hands a db.CustomRun off to an external controller. Submit
+// should return as soon as the controller has accepted the run; the
+// controller is responsible for reporting progress back via
+// PUT /api/v1/custom-runs/:id/status as the run proceeds.
+type CustomExecutor interface {
+	Submit(lager.Logger, db.CustomRun) error
+}
+
+//go:generate counterfeiter . CustomExecutorResolver
+
+// CustomExecutorResolver looks up the CustomExecutor registered for a given
+// custom step type, e.g. "tekton" or "approval-gate".
+type CustomExecutorResolver interface {
+	Resolve(stepType string) (CustomExecutor, bool)
+}
+
+// StaticCustomExecutorResolver resolves step types against a fixed,
+// pre-configured set of executors, handed to NewBuildStarter at startup.
+type StaticCustomExecutorResolver map[string]CustomExecutor
+
+func (executors StaticCustomExecutorResolver) Resolve(stepType string) (CustomExecutor, bool) {
+	executor, found := executors[stepType]
+	return executor, found
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . FinishableBuild
+
+// FinishableBuild is the part of Build that CustomRunPoller needs: just
+// enough to finish it once its custom run has a terminal status.
+type FinishableBuild interface {
+	Finish(db.BuildStatus) error
+}
+
+//go:generate counterfeiter . CustomRunBuildLookup
+
+// CustomRunBuildLookup looks up the build a db.CustomRun belongs to, so a
+// CustomRunPoller can feed its terminal status into Build.Finish.
+type CustomRunBuildLookup interface {
+	LookupBuild(id int) (FinishableBuild, bool, error)
+}
+
+// CustomRunPoller reconciles db.CustomRuns whose external controller has
+// reported a terminal status with the build that's waiting on them.
+// customrunserver.UpdateCustomRunStatus only ever persists the reported
+// status; Poll is what actually finishes the build, and is expected to be
+// called periodically alongside TryStartPendingBuildsForJob.
+type CustomRunPoller struct {
+	CustomRuns db.CustomRunFactory
+	Builds     CustomRunBuildLookup
+}
+
+// Poll finishes every build whose custom run has reached a terminal status.
+// Lookup or Finish failures for one run are logged and skipped rather than
+// aborting the rest of the batch.
+func (poller CustomRunPoller) Poll(logger lager.Logger) error {
+	logger = logger.Session("poll-custom-runs")
+
+	runs, err := poller.CustomRuns.ListTerminated()
+	if err != nil {
+		return fmt.Errorf("list terminated custom runs: %w", err)
+	}
+
+	for _, run := range runs {
+		runLogger := logger.WithData(lager.Data{"custom-run-id": run.ID(), "build-id": run.BuildID()})
+
+		build, found, err := poller.Builds.LookupBuild(run.BuildID())
+		if err != nil {
+			runLogger.Error("failed-to-lookup-build", err)
+			continue
+		}
+
+		if !found {
+			runLogger.Info("build-not-found")
+			continue
+		}
+
+		if err := build.Finish(buildStatusForCustomRun(run.Status())); err != nil {
+			runLogger.Error("failed-to-finish-build", err)
+			continue
+		}
+	}
+
+	return nil
+}
+
+// buildStatusForCustomRun maps a terminal CustomRunStatus to the
+// db.BuildStatus its build should be finished with.
+func buildStatusForCustomRun(status db.CustomRunStatus) db.BuildStatus {
+	switch status {
+	case db.CustomRunStatusSucceeded:
+		return db.BuildStatusSucceeded
+	default:
+		return db.BuildStatusFailed
+	}
+}
@@ -0,0 +1,148 @@
+package scheduler_test
+
+import (
+	"errors"
+	"testing"
+
+	"code.cloudfoundry.org/lager/lagertest"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/concourse/concourse/atc/scheduler"
+)
+
+type fakeCustomRunFactory struct {
+	terminated []db.CustomRun
+	listErr    error
+}
+
+func (f *fakeCustomRunFactory) Create(int, atc.PlanID, string, map[string]string) (db.CustomRun, error) {
+	panic("not used by CustomRunPoller")
+}
+
+func (f *fakeCustomRunFactory) Lookup(string) (db.CustomRun, bool, error) {
+	panic("not used by CustomRunPoller")
+}
+
+func (f *fakeCustomRunFactory) ListTerminated() ([]db.CustomRun, error) {
+	return f.terminated, f.listErr
+}
+
+type fakeCustomRun struct {
+	id      int
+	buildID int
+	status  db.CustomRunStatus
+}
+
+func (r *fakeCustomRun) ID() int                               { return r.id }
+func (r *fakeCustomRun) BuildID() int                          { return r.buildID }
+func (r *fakeCustomRun) PlanID() atc.PlanID                    { return "" }
+func (r *fakeCustomRun) Type() string                          { return "" }
+func (r *fakeCustomRun) Params() map[string]string             { return nil }
+func (r *fakeCustomRun) Status() db.CustomRunStatus            { return r.status }
+func (r *fakeCustomRun) UpdateStatus(db.CustomRunStatus) error { return nil }
+
+type fakeBuild struct {
+	finishedWith db.BuildStatus
+	finishErr    error
+}
+
+func (b *fakeBuild) Finish(status db.BuildStatus) error {
+	b.finishedWith = status
+	return b.finishErr
+}
+
+type fakeBuildLookup struct {
+	builds map[int]*fakeBuild
+}
+
+func (l *fakeBuildLookup) LookupBuild(id int) (FinishableBuild, bool, error) {
+	build, found := l.builds[id]
+	if !found {
+		return nil, false, nil
+	}
+
+	return build, true, nil
+}
+
+func TestCustomRunPoller_FinishesBuildsForTerminatedRuns(t *testing.T) {
+	succeededBuild := &fakeBuild{}
+	failedBuild := &fakeBuild{}
+
+	poller := CustomRunPoller{
+		CustomRuns: &fakeCustomRunFactory{
+			terminated: []db.CustomRun{
+				&fakeCustomRun{id: 1, buildID: 10, status: db.CustomRunStatusSucceeded},
+				&fakeCustomRun{id: 2, buildID: 20, status: db.CustomRunStatusFailed},
+			},
+		},
+		Builds: &fakeBuildLookup{builds: map[int]*fakeBuild{
+			10: succeededBuild,
+			20: failedBuild,
+		}},
+	}
+
+	if err := poller.Poll(lagertest.NewTestLogger("test")); err != nil {
+		t.Fatalf("Poll: %s", err)
+	}
+
+	if succeededBuild.finishedWith != db.BuildStatusSucceeded {
+		t.Errorf("succeeded build finished with %q, want %q", succeededBuild.finishedWith, db.BuildStatusSucceeded)
+	}
+
+	if failedBuild.finishedWith != db.BuildStatusFailed {
+		t.Errorf("failed build finished with %q, want %q", failedBuild.finishedWith, db.BuildStatusFailed)
+	}
+}
+
+func TestCustomRunPoller_SkipsRunsWithNoMatchingBuild(t *testing.T) {
+	poller := CustomRunPoller{
+		CustomRuns: &fakeCustomRunFactory{
+			terminated: []db.CustomRun{
+				&fakeCustomRun{id: 1, buildID: 10, status: db.CustomRunStatusSucceeded},
+			},
+		},
+		Builds: &fakeBuildLookup{builds: map[int]*fakeBuild{}},
+	}
+
+	if err := poller.Poll(lagertest.NewTestLogger("test")); err != nil {
+		t.Fatalf("Poll: %s, want no error when the build is simply missing", err)
+	}
+}
+
+func TestCustomRunPoller_ContinuesPastAFinishError(t *testing.T) {
+	badBuild := &fakeBuild{finishErr: errors.New("db is down")}
+	goodBuild := &fakeBuild{}
+
+	poller := CustomRunPoller{
+		CustomRuns: &fakeCustomRunFactory{
+			terminated: []db.CustomRun{
+				&fakeCustomRun{id: 1, buildID: 10, status: db.CustomRunStatusSucceeded},
+				&fakeCustomRun{id: 2, buildID: 20, status: db.CustomRunStatusSucceeded},
+			},
+		},
+		Builds: &fakeBuildLookup{builds: map[int]*fakeBuild{
+			10: badBuild,
+			20: goodBuild,
+		}},
+	}
+
+	if err := poller.Poll(lagertest.NewTestLogger("test")); err != nil {
+		t.Fatalf("Poll: %s, want per-run Finish errors to be logged and skipped, not returned", err)
+	}
+
+	if goodBuild.finishedWith != db.BuildStatusSucceeded {
+		t.Errorf("goodBuild.finishedWith = %q, want the later run to still be finished despite the earlier error", goodBuild.finishedWith)
+	}
+}
+
+func TestCustomRunPoller_PropagatesListError(t *testing.T) {
+	poller := CustomRunPoller{
+		CustomRuns: &fakeCustomRunFactory{listErr: errors.New("query failed")},
+		Builds:     &fakeBuildLookup{builds: map[int]*fakeBuild{}},
+	}
+
+	if err := poller.Poll(lagertest.NewTestLogger("test")); err == nil {
+		t.Fatal("Poll: expected an error when ListTerminated fails, got none")
+	}
+}
@@ -0,0 +1,121 @@
+package atc
+
+import "gopkg.in/yaml.v2"
+
+// TaskConfig is the configuration for a Task step, as loaded from a task
+// config file or defined inline in a pipeline.
+type TaskConfig struct {
+	Platform string `json:"platform,omitempty" yaml:"platform,omitempty"`
+
+	Params map[string]string `json:"params,omitempty" yaml:"params,omitempty"`
+
+	Run TaskRunConfig `json:"run,omitempty" yaml:"run,omitempty"`
+
+	Inputs  []TaskInputConfig  `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	Outputs []TaskOutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+
+	// Provenance records where this TaskConfig's bytes came from, so it can
+	// be attached to the build plan it ends up part of. It is never present
+	// in the task config file itself; TaskConfigSources set it after
+	// loading/assembling the config.
+	Provenance ConfigSourceProvenance `json:"provenance,omitempty" yaml:"-"`
+}
+
+// TaskRunConfig specifies the executable to run, and the arguments to pass
+// to it.
+type TaskRunConfig struct {
+	Path string   `json:"path,omitempty" yaml:"path,omitempty"`
+	Args []string `json:"args,omitempty" yaml:"args,omitempty"`
+	Dir  string   `json:"dir,omitempty" yaml:"dir,omitempty"`
+}
+
+// TaskInputConfig is an input expected to be provided to a Task step.
+type TaskInputConfig struct {
+	Name     string `json:"name" yaml:"name"`
+	Path     string `json:"path,omitempty" yaml:"path,omitempty"`
+	Optional bool   `json:"optional,omitempty" yaml:"optional,omitempty"`
+}
+
+// TaskOutputConfig is an output a Task step produces.
+type TaskOutputConfig struct {
+	Name string `json:"name" yaml:"name"`
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
+// TaskPlan is the configuration given directly on a task step within a
+// pipeline, as opposed to in a separate task config file.
+type TaskPlan struct {
+	ConfigPath string                 `json:"config_path,omitempty"`
+	Config     *TaskConfig            `json:"config,omitempty"`
+	Params     map[string]interface{} `json:"params,omitempty"`
+}
+
+// LoadTaskConfig parses raw YAML bytes (e.g. streamed out of an input, or
+// fetched from a git ref) into a TaskConfig.
+func LoadTaskConfig(bytes []byte) (TaskConfig, error) {
+	var config TaskConfig
+
+	if err := yaml.Unmarshal(bytes, &config); err != nil {
+		return TaskConfig{}, err
+	}
+
+	return config, nil
+}
+
+// Merge combines other into config, with other's params taking precedence
+// over config's, and other's platform/run/inputs/outputs replacing config's
+// wholesale when set. Provenance is deliberately left alone; callers that
+// merge two sourced configs are expected to set their own combined
+// Provenance afterward.
+func (config TaskConfig) Merge(other TaskConfig) TaskConfig {
+	merged := config
+
+	if other.Platform != "" {
+		merged.Platform = other.Platform
+	}
+
+	if merged.Params == nil {
+		merged.Params = map[string]string{}
+	}
+
+	for name, val := range other.Params {
+		merged.Params[name] = val
+	}
+
+	if other.Run.Path != "" {
+		merged.Run = other.Run
+	}
+
+	if len(other.Inputs) > 0 {
+		merged.Inputs = other.Inputs
+	}
+
+	if len(other.Outputs) > 0 {
+		merged.Outputs = other.Outputs
+	}
+
+	return merged
+}
+
+// Validate returns an error if config isn't runnable.
+func (config TaskConfig) Validate() error {
+	if config.Platform == "" {
+		return MalformedTaskConfigError{"missing 'platform'"}
+	}
+
+	if config.Run.Path == "" {
+		return MalformedTaskConfigError{"missing path to executable to run"}
+	}
+
+	return nil
+}
+
+// MalformedTaskConfigError is returned by TaskConfig.Validate when the
+// config is missing required fields.
+type MalformedTaskConfigError struct {
+	Reason string
+}
+
+func (err MalformedTaskConfigError) Error() string {
+	return "invalid task configuration: " + err.Reason
+}
@@ -0,0 +1,49 @@
+package atc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ConfigSourceProvenance records where a TaskConfig's bytes came from: which
+// source produced them, a content digest, and the path within that source.
+type ConfigSourceProvenance struct {
+	// URI identifies the location the config was fetched from, e.g.
+	// "artifact://some-input/task.yml", "git+https://example.com/repo//task.yml@<sha>",
+	// or "static://inline" for a config embedded directly in the pipeline.
+	URI string `json:"uri"`
+
+	// Digest is the content digest of the raw bytes that were passed to
+	// LoadTaskConfig, in the form "ALGORITHM:HEX".
+	Digest string `json:"digest"`
+
+	// EntryPoint is the path to the config file within its source, relative
+	// to the root identified by URI. It is empty for inline configs.
+	EntryPoint string `json:"entry_point,omitempty"`
+
+	// Parents records the provenance of the configs that were merged to
+	// produce this one, e.g. when a MergedConfigSource combines a config
+	// fetched from a file with statically configured params.
+	Parents []ConfigSourceProvenance `json:"parents,omitempty"`
+}
+
+// DigestConfigBytes computes the default content digest used for
+// ConfigSourceProvenance.Digest.
+func DigestConfigBytes(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// TaskConfigProvenance walks the plan tree and collects the provenance of
+// every task config contributing to the build, in plan-tree order.
+func (plan Plan) TaskConfigProvenance() []ConfigSourceProvenance {
+	var provenance []ConfigSourceProvenance
+
+	plan.Each(func(p Plan) {
+		if p.Task != nil && p.Task.Config != nil {
+			provenance = append(provenance, p.Task.Config.Provenance)
+		}
+	})
+
+	return provenance
+}
@@ -0,0 +1,43 @@
+package atc_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func TestTaskConfig_ProvenanceSurvivesJSONRoundTrip(t *testing.T) {
+	plan := atc.Plan{
+		ID: "1",
+		Task: &atc.TaskStepPlan{
+			Name: "build",
+			Config: &atc.TaskConfig{
+				Platform: "linux",
+				Run:      atc.TaskRunConfig{Path: "echo"},
+				Provenance: atc.ConfigSourceProvenance{
+					URI:        "git+https://example.com/repo//task.yml@deadbeef",
+					Digest:     "sha256:abc123",
+					EntryPoint: "task.yml",
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatalf("Marshal: %s", err)
+	}
+
+	var round atc.Plan
+	if err := json.Unmarshal(payload, &round); err != nil {
+		t.Fatalf("Unmarshal: %s", err)
+	}
+
+	got := round.Task.Config.Provenance
+	want := plan.Task.Config.Provenance
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Provenance = %+v after a JSON round-trip, want %+v", got, want)
+	}
+}
@@ -0,0 +1,171 @@
+package exec
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+// RemoteConfigSource is implemented by TaskConfigSources that fetch a task
+// config from somewhere outside the SourceRepository (e.g. GitConfigSource),
+// so that their results can be safely cached across builds.
+type RemoteConfigSource interface {
+	TaskConfigSource
+
+	// Identity returns a stable identifier for where this source fetches
+	// from (e.g. a repository URL) and the revision it was asked to fetch
+	// (e.g. a branch name or commit SHA). Together they form the cache key.
+	Identity() (sourceIdentity string, revision string)
+
+	// Immutable reports whether the requested revision always resolves to
+	// the same content, e.g. a commit SHA or OCI digest, as opposed to a
+	// branch name that can move.
+	Immutable() bool
+}
+
+// CachingConfigSource wraps a RemoteConfigSource with a shared ConfigCache,
+// keyed by the source's identity and revision. Immutable revisions are
+// served straight from the cache without touching the underlying source;
+// mutable ones are always refetched, but the previously parsed TaskConfig is
+// reused if its content digest hasn't changed.
+type CachingConfigSource struct {
+	Source RemoteConfigSource
+	Cache  *ConfigCache
+}
+
+func (configSource CachingConfigSource) FetchConfig(repo *SourceRepository) (atc.TaskConfig, error) {
+	sourceIdentity, revision := configSource.Source.Identity()
+	key := configCacheKey{sourceIdentity: sourceIdentity, revision: revision}
+
+	immutable := configSource.Source.Immutable()
+
+	if immutable {
+		if cached, found := configSource.Cache.get(key); found {
+			metric.ConfigCacheHits.Inc()
+			return cached.config, nil
+		}
+	}
+
+	metric.ConfigCacheMisses.Inc()
+
+	config, err := configSource.Source.FetchConfig(repo)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	if cached, found := configSource.Cache.get(key); found && cached.digest == config.Provenance.Digest {
+		config = cached.config
+	}
+
+	configSource.Cache.put(key, config.Provenance.Digest, config, immutable)
+
+	return config, nil
+}
+
+func (configSource CachingConfigSource) Warnings() []string {
+	return configSource.Source.Warnings()
+}
+
+// ConfigCache is a process-wide, size-bounded cache of fetched TaskConfigs,
+// shared across CachingConfigSources. Entries for immutable revisions never
+// expire; entries for mutable ones are capped by TTL and are always
+// revalidated against the source, though the parsed TaskConfig is reused
+// when its content digest hasn't changed.
+type ConfigCache struct {
+	size int
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[configCacheKey]configCacheEntry
+	lru     []configCacheKey
+}
+
+type configCacheKey struct {
+	sourceIdentity string
+	revision       string
+}
+
+type configCacheEntry struct {
+	digest    string
+	config    atc.TaskConfig
+	expiresAt time.Time
+}
+
+// NewConfigCache constructs a ConfigCache that holds at most size entries,
+// evicting the least-recently-used one once full. A size of 0 disables the
+// entry limit. ttl bounds how long a mutable (non-immutable) revision's
+// entry is kept before it is dropped outright; a ttl of 0 disables expiry.
+func NewConfigCache(size int, ttl time.Duration) *ConfigCache {
+	return &ConfigCache{
+		size:    size,
+		ttl:     ttl,
+		entries: map[configCacheKey]configCacheEntry{},
+	}
+}
+
+func (cache *ConfigCache) get(key configCacheKey) (configCacheEntry, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entry, found := cache.entries[key]
+	if !found {
+		return configCacheEntry{}, false
+	}
+
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(cache.entries, key)
+		cache.removeFromLRU(key)
+		return configCacheEntry{}, false
+	}
+
+	cache.touch(key)
+
+	return entry, true
+}
+
+func (cache *ConfigCache) put(key configCacheKey, digest string, config atc.TaskConfig, immutable bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	entry := configCacheEntry{
+		digest: digest,
+		config: config,
+	}
+
+	if !immutable && cache.ttl > 0 {
+		entry.expiresAt = time.Now().Add(cache.ttl)
+	}
+
+	if _, exists := cache.entries[key]; !exists && cache.size > 0 && len(cache.entries) >= cache.size {
+		cache.evictOldest()
+	}
+
+	cache.entries[key] = entry
+	cache.touch(key)
+}
+
+func (cache *ConfigCache) touch(key configCacheKey) {
+	cache.removeFromLRU(key)
+	cache.lru = append(cache.lru, key)
+}
+
+func (cache *ConfigCache) removeFromLRU(key configCacheKey) {
+	for i, k := range cache.lru {
+		if k == key {
+			cache.lru = append(cache.lru[:i], cache.lru[i+1:]...)
+			return
+		}
+	}
+}
+
+func (cache *ConfigCache) evictOldest() {
+	if len(cache.lru) == 0 {
+		return
+	}
+
+	oldest := cache.lru[0]
+	cache.lru = cache.lru[1:]
+	delete(cache.entries, oldest)
+}
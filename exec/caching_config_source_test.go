@@ -0,0 +1,186 @@
+package exec_test
+
+import (
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/exec"
+)
+
+type fakeRemoteConfigSource struct {
+	sourceIdentity string
+	revision       string
+	immutable      bool
+
+	fetches int
+	config  atc.TaskConfig
+	err     error
+}
+
+func (s *fakeRemoteConfigSource) Identity() (string, string) {
+	return s.sourceIdentity, s.revision
+}
+
+func (s *fakeRemoteConfigSource) Immutable() bool {
+	return s.immutable
+}
+
+func (s *fakeRemoteConfigSource) FetchConfig(*SourceRepository) (atc.TaskConfig, error) {
+	s.fetches++
+	return s.config, s.err
+}
+
+func (s *fakeRemoteConfigSource) Warnings() []string {
+	return nil
+}
+
+func TestCachingConfigSource_ImmutableRevisionIsServedFromCache(t *testing.T) {
+	source := &fakeRemoteConfigSource{
+		sourceIdentity: "git://repo",
+		revision:       "abc123",
+		immutable:      true,
+		config:         atc.TaskConfig{Platform: "linux", Provenance: atc.ConfigSourceProvenance{Digest: "digest-1"}},
+	}
+
+	caching := CachingConfigSource{Source: source, Cache: NewConfigCache(0, 0)}
+
+	for i := 0; i < 3; i++ {
+		got, err := caching.FetchConfig(nil)
+		if err != nil {
+			t.Fatalf("FetchConfig: %s", err)
+		}
+
+		if got.Platform != "linux" {
+			t.Errorf("got %+v, want Platform linux", got)
+		}
+	}
+
+	if source.fetches != 1 {
+		t.Errorf("fetches = %d, want 1 (immutable revision should only be fetched once)", source.fetches)
+	}
+}
+
+func TestCachingConfigSource_MutableRevisionAlwaysRefetches(t *testing.T) {
+	source := &fakeRemoteConfigSource{
+		sourceIdentity: "git://repo",
+		revision:       "main",
+		immutable:      false,
+		config:         atc.TaskConfig{Platform: "linux", Provenance: atc.ConfigSourceProvenance{Digest: "digest-1"}},
+	}
+
+	caching := CachingConfigSource{Source: source, Cache: NewConfigCache(0, 0)}
+
+	for i := 0; i < 3; i++ {
+		_, err := caching.FetchConfig(nil)
+		if err != nil {
+			t.Fatalf("FetchConfig: %s", err)
+		}
+	}
+
+	if source.fetches != 3 {
+		t.Errorf("fetches = %d, want 3 (mutable revision should be refetched every time)", source.fetches)
+	}
+}
+
+func TestCachingConfigSource_MutableRevisionReusesParsedConfigWhenDigestUnchanged(t *testing.T) {
+	source := &fakeRemoteConfigSource{
+		sourceIdentity: "git://repo",
+		revision:       "main",
+		immutable:      false,
+		config:         atc.TaskConfig{Platform: "linux", Provenance: atc.ConfigSourceProvenance{Digest: "digest-1"}},
+	}
+
+	caching := CachingConfigSource{Source: source, Cache: NewConfigCache(0, 0)}
+
+	first, err := caching.FetchConfig(nil)
+	if err != nil {
+		t.Fatalf("FetchConfig: %s", err)
+	}
+
+	source.config.Platform = "windows"
+
+	second, err := caching.FetchConfig(nil)
+	if err != nil {
+		t.Fatalf("FetchConfig: %s", err)
+	}
+
+	if second.Platform != first.Platform {
+		t.Errorf("Platform = %q, want the cached %q since the digest didn't change", second.Platform, first.Platform)
+	}
+}
+
+func TestCachingConfigSource_MutableRevisionRefetchesParsedConfigWhenDigestChanges(t *testing.T) {
+	source := &fakeRemoteConfigSource{
+		sourceIdentity: "git://repo",
+		revision:       "main",
+		immutable:      false,
+		config:         atc.TaskConfig{Platform: "linux", Provenance: atc.ConfigSourceProvenance{Digest: "digest-1"}},
+	}
+
+	caching := CachingConfigSource{Source: source, Cache: NewConfigCache(0, 0)}
+
+	if _, err := caching.FetchConfig(nil); err != nil {
+		t.Fatalf("FetchConfig: %s", err)
+	}
+
+	source.config = atc.TaskConfig{Platform: "windows", Provenance: atc.ConfigSourceProvenance{Digest: "digest-2"}}
+
+	got, err := caching.FetchConfig(nil)
+	if err != nil {
+		t.Fatalf("FetchConfig: %s", err)
+	}
+
+	if got.Platform != "windows" {
+		t.Errorf("Platform = %q, want windows once the digest changes", got.Platform)
+	}
+}
+
+func TestCachingConfigSource_SharedCacheEvictsLeastRecentlyUsedOnceFull(t *testing.T) {
+	cache := NewConfigCache(2, 0)
+
+	a := &fakeRemoteConfigSource{sourceIdentity: "repo", revision: "a", immutable: true, config: atc.TaskConfig{Platform: "a"}}
+	b := &fakeRemoteConfigSource{sourceIdentity: "repo", revision: "b", immutable: true, config: atc.TaskConfig{Platform: "b"}}
+	c := &fakeRemoteConfigSource{sourceIdentity: "repo", revision: "c", immutable: true, config: atc.TaskConfig{Platform: "c"}}
+
+	cachingA := CachingConfigSource{Source: a, Cache: cache}
+	cachingB := CachingConfigSource{Source: b, Cache: cache}
+	cachingC := CachingConfigSource{Source: c, Cache: cache}
+
+	mustFetch(t, cachingA)
+	mustFetch(t, cachingB)
+
+	// Re-fetch A so B becomes the least-recently-used entry.
+	mustFetch(t, cachingA)
+
+	// The cache is now full, so adding C evicts B.
+	mustFetch(t, cachingC)
+
+	// A and C are still cached; check both before touching B below, since
+	// fetching B back in evicts whichever of them is now least recently used.
+	mustFetch(t, cachingA)
+	if a.fetches != 1 {
+		t.Errorf("a.fetches = %d, want 1 (A should still be cached)", a.fetches)
+	}
+
+	mustFetch(t, cachingC)
+	if c.fetches != 1 {
+		t.Errorf("c.fetches = %d, want 1 (C should still be cached)", c.fetches)
+	}
+
+	// B was evicted to make room for C, so fetching it again hits the source.
+	mustFetch(t, cachingB)
+	if b.fetches != 2 {
+		t.Errorf("b.fetches = %d, want 2 (B should have been evicted and refetched)", b.fetches)
+	}
+}
+
+func mustFetch(t *testing.T, source CachingConfigSource) atc.TaskConfig {
+	t.Helper()
+
+	config, err := source.FetchConfig(nil)
+	if err != nil {
+		t.Fatalf("FetchConfig: %s", err)
+	}
+
+	return config
+}
@@ -0,0 +1,85 @@
+package exec
+
+import (
+	"testing"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+)
+
+func TestConfigCache_MutableEntryExpiresAfterTTL(t *testing.T) {
+	cache := NewConfigCache(0, time.Millisecond)
+	key := configCacheKey{sourceIdentity: "repo", revision: "main"}
+
+	cache.put(key, "digest-1", taskConfigWithPlatform("linux"), false)
+
+	if _, found := cache.get(key); !found {
+		t.Fatalf("expected entry to be cached immediately after put")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, found := cache.get(key); found {
+		t.Errorf("expected mutable entry to have expired after its TTL")
+	}
+}
+
+func TestConfigCache_ImmutableEntryNeverExpires(t *testing.T) {
+	cache := NewConfigCache(0, time.Millisecond)
+	key := configCacheKey{sourceIdentity: "repo", revision: "abc123"}
+
+	cache.put(key, "digest-1", taskConfigWithPlatform("linux"), true)
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, found := cache.get(key); !found {
+		t.Errorf("expected immutable entry to survive past the TTL")
+	}
+}
+
+func TestConfigCache_EvictsLeastRecentlyUsedOnceFull(t *testing.T) {
+	cache := NewConfigCache(2, 0)
+
+	keyA := configCacheKey{sourceIdentity: "repo", revision: "a"}
+	keyB := configCacheKey{sourceIdentity: "repo", revision: "b"}
+	keyC := configCacheKey{sourceIdentity: "repo", revision: "c"}
+
+	cache.put(keyA, "digest-a", taskConfigWithPlatform("a"), true)
+	cache.put(keyB, "digest-b", taskConfigWithPlatform("b"), true)
+
+	// Touch A so B becomes the least-recently-used entry.
+	if _, found := cache.get(keyA); !found {
+		t.Fatalf("expected to find key A before eviction")
+	}
+
+	cache.put(keyC, "digest-c", taskConfigWithPlatform("c"), true)
+
+	if _, found := cache.get(keyB); found {
+		t.Errorf("key B should have been evicted as the least-recently-used entry")
+	}
+
+	if _, found := cache.get(keyA); !found {
+		t.Errorf("key A should still be cached")
+	}
+
+	if _, found := cache.get(keyC); !found {
+		t.Errorf("key C should still be cached")
+	}
+}
+
+func TestConfigCache_SizeZeroDisablesEviction(t *testing.T) {
+	cache := NewConfigCache(0, 0)
+
+	for i := 0; i < 10; i++ {
+		key := configCacheKey{sourceIdentity: "repo", revision: string(rune('a' + i))}
+		cache.put(key, "digest", taskConfigWithPlatform("linux"), true)
+	}
+
+	if len(cache.entries) != 10 {
+		t.Errorf("len(entries) = %d, want 10 (size 0 should disable the entry limit)", len(cache.entries))
+	}
+}
+
+func taskConfigWithPlatform(platform string) atc.TaskConfig {
+	return atc.TaskConfig{Platform: platform}
+}
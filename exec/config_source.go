@@ -7,7 +7,7 @@ import (
 	"io/ioutil"
 	"strings"
 
-	"github.com/concourse/atc"
+	"github.com/concourse/concourse/atc"
 )
 
 //go:generate counterfeiter . TaskConfigSource
@@ -40,6 +40,16 @@ func (configSource StaticConfigSource) FetchConfig(*SourceRepository) (atc.TaskC
 		taskConfig.Params[key] = strVal
 	}
 
+	raw, err := json.Marshal(configSource.Plan)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	taskConfig.Provenance = atc.ConfigSourceProvenance{
+		URI:    "static://inline",
+		Digest: atc.DigestConfigBytes(raw),
+	}
+
 	return taskConfig, nil
 }
 
@@ -138,6 +148,12 @@ func (configSource FileConfigSource) FetchConfig(repo *SourceRepository) (atc.Ta
 		return atc.TaskConfig{}, fmt.Errorf("failed to load %s: %s", configSource.Path, err)
 	}
 
+	config.Provenance = atc.ConfigSourceProvenance{
+		URI:        fmt.Sprintf("artifact://%s/%s", sourceName, filePath),
+		Digest:     atc.DigestConfigBytes(streamedFile),
+		EntryPoint: filePath,
+	}
+
 	return config, nil
 }
 
@@ -165,7 +181,13 @@ func (configSource MergedConfigSource) FetchConfig(source *SourceRepository) (at
 		return atc.TaskConfig{}, err
 	}
 
-	return aConfig.Merge(bConfig), nil
+	merged := aConfig.Merge(bConfig)
+	merged.Provenance = atc.ConfigSourceProvenance{
+		URI:     "merged://",
+		Parents: []atc.ConfigSourceProvenance{aConfig.Provenance, bConfig.Provenance},
+	}
+
+	return merged, nil
 }
 
 func (configSource MergedConfigSource) Warnings() []string {
@@ -212,6 +234,12 @@ func (err UnknownArtifactSourceError) Error() string {
 	return fmt.Sprintf("unknown artifact source: %s", err.SourceName)
 }
 
+// NotApplicable returns true, since this error means the path simply didn't
+// resolve to a source, not that the source it resolved to was invalid.
+func (err UnknownArtifactSourceError) NotApplicable() bool {
+	return true
+}
+
 // UnspecifiedArtifactSourceError is returned when the specified path is of a
 // file in the toplevel directory, and so it does not indicate a SourceName.
 type UnspecifiedArtifactSourceError struct {
@@ -222,3 +250,9 @@ type UnspecifiedArtifactSourceError struct {
 func (err UnspecifiedArtifactSourceError) Error() string {
 	return fmt.Sprintf("config path '%s' does not specify where the file lives", err.Path)
 }
+
+// NotApplicable returns true, since this error means the path simply didn't
+// specify a source, not that the source it specified was invalid.
+func (err UnspecifiedArtifactSourceError) NotApplicable() bool {
+	return true
+}
@@ -0,0 +1,81 @@
+package exec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// NotApplicableError is implemented by TaskConfigSource errors that mean the
+// source simply didn't have anything to fetch (e.g. an input wasn't present,
+// or a git ref couldn't be resolved), as opposed to having found something
+// that turned out to be invalid. FallbackConfigSource uses it to decide
+// whether to try the next candidate or give up.
+type NotApplicableError interface {
+	error
+	NotApplicable() bool
+}
+
+// FallbackConfigSource holds an ordered list of TaskConfigSource candidates
+// and returns the first one whose FetchConfig succeeds. This lets a pipeline
+// express, for example, "use task.yml from the input if present, else fall
+// back to an inline config, else fetch from a pinned git ref".
+//
+// A candidate that fails with a NotApplicableError is skipped in favor of
+// the next one. Any other error (e.g. malformed YAML, a failed validation)
+// is treated as the source having applied but being broken, and is returned
+// immediately rather than falling through.
+type FallbackConfigSource struct {
+	Sources []TaskConfigSource
+}
+
+// FetchConfig tries each source in order, returning the first successful
+// TaskConfig. If every source is not applicable, the aggregated failures are
+// returned as a FallbackConfigSourceError.
+func (configSource FallbackConfigSource) FetchConfig(repo *SourceRepository) (atc.TaskConfig, error) {
+	var notApplicable []string
+
+	for _, source := range configSource.Sources {
+		config, err := source.FetchConfig(repo)
+		if err == nil {
+			return config, nil
+		}
+
+		var applicableErr NotApplicableError
+		if !errors.As(err, &applicableErr) || !applicableErr.NotApplicable() {
+			return atc.TaskConfig{}, err
+		}
+
+		notApplicable = append(notApplicable, fmt.Sprintf("%T: %s", source, err))
+	}
+
+	return atc.TaskConfig{}, FallbackConfigSourceError{Errors: notApplicable}
+}
+
+// Warnings returns the union of all candidates' warnings, each prefixed with
+// the identity of the source it came from.
+func (configSource FallbackConfigSource) Warnings() []string {
+	var warnings []string
+
+	for _, source := range configSource.Sources {
+		for _, warning := range source.Warnings() {
+			warnings = append(warnings, fmt.Sprintf("[%T] %s", source, warning))
+		}
+	}
+
+	return warnings
+}
+
+// FallbackConfigSourceError is returned when every candidate in a
+// FallbackConfigSource was not applicable.
+type FallbackConfigSourceError struct {
+	Errors []string
+}
+
+// Error returns a human-friendly error message listing why each candidate
+// was skipped.
+func (err FallbackConfigSourceError) Error() string {
+	return fmt.Sprintf("no task config source applied:\n%s", strings.Join(err.Errors, "\n"))
+}
@@ -0,0 +1,154 @@
+package exec_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/exec"
+)
+
+type fakeConfigSource struct {
+	config   atc.TaskConfig
+	err      error
+	warnings []string
+}
+
+func (s fakeConfigSource) FetchConfig(*SourceRepository) (atc.TaskConfig, error) {
+	return s.config, s.err
+}
+
+func (s fakeConfigSource) Warnings() []string {
+	return s.warnings
+}
+
+type notApplicableErr struct{}
+
+func (notApplicableErr) Error() string       { return "not applicable" }
+func (notApplicableErr) NotApplicable() bool { return true }
+
+type wrappedNotApplicableErr struct {
+	err error
+}
+
+func (w wrappedNotApplicableErr) Error() string { return fmt.Sprintf("wrapped: %s", w.err) }
+func (w wrappedNotApplicableErr) Unwrap() error { return w.err }
+
+type hardErr struct{}
+
+func (hardErr) Error() string { return "malformed config" }
+
+func TestFallbackConfigSource_FirstSucceeds(t *testing.T) {
+	want := atc.TaskConfig{Platform: "linux"}
+
+	source := FallbackConfigSource{
+		Sources: []TaskConfigSource{
+			fakeConfigSource{config: want},
+			fakeConfigSource{err: hardErr{}},
+		},
+	}
+
+	got, err := source.FetchConfig(nil)
+	if err != nil {
+		t.Fatalf("FetchConfig: %s", err)
+	}
+
+	if got.Platform != want.Platform {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestFallbackConfigSource_SkipsNotApplicable(t *testing.T) {
+	want := atc.TaskConfig{Platform: "linux"}
+
+	source := FallbackConfigSource{
+		Sources: []TaskConfigSource{
+			fakeConfigSource{err: notApplicableErr{}},
+			fakeConfigSource{config: want},
+		},
+	}
+
+	got, err := source.FetchConfig(nil)
+	if err != nil {
+		t.Fatalf("FetchConfig: %s", err)
+	}
+
+	if got.Platform != want.Platform {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestFallbackConfigSource_SkipsWrappedNotApplicable(t *testing.T) {
+	want := atc.TaskConfig{Platform: "linux"}
+
+	source := FallbackConfigSource{
+		Sources: []TaskConfigSource{
+			fakeConfigSource{err: wrappedNotApplicableErr{err: notApplicableErr{}}},
+			fakeConfigSource{config: want},
+		},
+	}
+
+	got, err := source.FetchConfig(nil)
+	if err != nil {
+		t.Fatalf("FetchConfig: %s", err)
+	}
+
+	if got.Platform != want.Platform {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestFallbackConfigSource_HardFailureShortCircuits(t *testing.T) {
+	source := FallbackConfigSource{
+		Sources: []TaskConfigSource{
+			fakeConfigSource{err: hardErr{}},
+			fakeConfigSource{config: atc.TaskConfig{Platform: "linux"}},
+		},
+	}
+
+	_, err := source.FetchConfig(nil)
+	if !errors.Is(err, hardErr{}) {
+		t.Errorf("err = %v, want the hard failure to be returned unchanged", err)
+	}
+}
+
+func TestFallbackConfigSource_AllNotApplicable(t *testing.T) {
+	source := FallbackConfigSource{
+		Sources: []TaskConfigSource{
+			fakeConfigSource{err: notApplicableErr{}},
+			fakeConfigSource{err: notApplicableErr{}},
+		},
+	}
+
+	_, err := source.FetchConfig(nil)
+
+	var fallbackErr FallbackConfigSourceError
+	if !errors.As(err, &fallbackErr) {
+		t.Fatalf("err = %v (%T), want a FallbackConfigSourceError", err, err)
+	}
+
+	if len(fallbackErr.Errors) != 2 {
+		t.Errorf("len(Errors) = %d, want 2", len(fallbackErr.Errors))
+	}
+}
+
+func TestFallbackConfigSource_WarningsAreUnionedAndPrefixed(t *testing.T) {
+	source := FallbackConfigSource{
+		Sources: []TaskConfigSource{
+			fakeConfigSource{warnings: []string{"a"}},
+			fakeConfigSource{warnings: []string{"b"}},
+		},
+	}
+
+	warnings := source.Warnings()
+	if len(warnings) != 2 {
+		t.Fatalf("Warnings() = %v, want 2 entries", warnings)
+	}
+
+	for _, w := range warnings {
+		if w != "[exec_test.fakeConfigSource] a" && w != "[exec_test.fakeConfigSource] b" {
+			t.Errorf("warning %q missing source-identity prefix", w)
+		}
+	}
+}
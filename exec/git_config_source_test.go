@@ -0,0 +1,165 @@
+package exec_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/concourse/concourse/exec"
+)
+
+func initFixtureRepo(t *testing.T, taskYAML string) (dir string, sha string) {
+	t.Helper()
+
+	dir = t.TempDir()
+
+	runGit(t, dir, "init", "--quiet", "-b", "master")
+	runGit(t, dir, "config", "user.email", "fixture@example.com")
+	runGit(t, dir, "config", "user.name", "fixture")
+
+	if err := os.WriteFile(filepath.Join(dir, "task.yml"), []byte(taskYAML), 0644); err != nil {
+		t.Fatalf("write fixture task.yml: %s", err)
+	}
+
+	runGit(t, dir, "add", "task.yml")
+	runGit(t, dir, "commit", "--quiet", "-m", "add task.yml")
+
+	return dir, strings.TrimSpace(runGit(t, dir, "rev-parse", "HEAD"))
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %s: %s", args, err, out)
+	}
+
+	return string(out)
+}
+
+const validTaskYAML = `
+platform: linux
+run:
+  path: echo
+  args: ["hello"]
+`
+
+func TestGitConfigSource_PinnedToSHA(t *testing.T) {
+	repoDir, sha := initFixtureRepo(t, validTaskYAML)
+
+	source := GitConfigSource{
+		URL:      repoDir,
+		Revision: sha,
+		Path:     "task.yml",
+	}
+
+	config, err := source.FetchConfig(nil)
+	if err != nil {
+		t.Fatalf("FetchConfig: %s", err)
+	}
+
+	if config.Run.Path != "echo" {
+		t.Errorf("Run.Path = %q, want %q", config.Run.Path, "echo")
+	}
+
+	if config.Provenance.EntryPoint != "task.yml" {
+		t.Errorf("Provenance.EntryPoint = %q, want %q", config.Provenance.EntryPoint, "task.yml")
+	}
+
+	if !strings.Contains(config.Provenance.URI, sha) {
+		t.Errorf("Provenance.URI = %q, want it to include resolved sha %q", config.Provenance.URI, sha)
+	}
+
+	if warnings := source.Warnings(); len(warnings) != 0 {
+		t.Errorf("Warnings() = %v, want none for a pinned sha", warnings)
+	}
+}
+
+func TestGitConfigSource_MutableRevisionWarns(t *testing.T) {
+	repoDir, _ := initFixtureRepo(t, validTaskYAML)
+
+	source := GitConfigSource{
+		URL:      repoDir,
+		Revision: "master",
+		Path:     "task.yml",
+	}
+
+	config, err := source.FetchConfig(nil)
+	if err != nil {
+		t.Fatalf("FetchConfig: %s", err)
+	}
+
+	if config.Run.Path != "echo" {
+		t.Errorf("Run.Path = %q, want %q", config.Run.Path, "echo")
+	}
+
+	if warnings := source.Warnings(); len(warnings) == 0 {
+		t.Errorf("Warnings() = %v, want a drift warning for a branch revision", warnings)
+	}
+}
+
+func TestGitConfigSource_UnknownRevisionIsNotApplicable(t *testing.T) {
+	repoDir, _ := initFixtureRepo(t, validTaskYAML)
+
+	source := GitConfigSource{
+		URL:      repoDir,
+		Revision: "does-not-exist",
+		Path:     "task.yml",
+	}
+
+	_, err := source.FetchConfig(nil)
+	if err == nil {
+		t.Fatal("FetchConfig: expected an error for an unknown revision, got none")
+	}
+
+	notApplicable, ok := err.(NotApplicableError)
+	if !ok || !notApplicable.NotApplicable() {
+		t.Errorf("FetchConfig err = %v (%T), want a NotApplicableError", err, err)
+	}
+}
+
+func TestGitConfigSource_RevisionStartingWithDashIsNotParsedAsAnOption(t *testing.T) {
+	repoDir, _ := initFixtureRepo(t, validTaskYAML)
+	canary := filepath.Join(t.TempDir(), "canary")
+
+	source := GitConfigSource{
+		URL:      repoDir,
+		Revision: fmt.Sprintf("--upload-pack=touch %s", canary),
+		Path:     "task.yml",
+	}
+
+	if _, err := source.FetchConfig(nil); err == nil {
+		t.Fatal("FetchConfig: expected an error for a malformed revision, got none")
+	}
+
+	if _, err := os.Stat(canary); err == nil {
+		t.Fatal("FetchConfig ran the revision as a git option instead of treating it as a ref")
+	}
+}
+
+func TestGitConfigSource_IdentityAndImmutable(t *testing.T) {
+	source := GitConfigSource{
+		URL:      "https://example.com/repo.git",
+		Revision: strings.Repeat("a", 40),
+		Path:     "task.yml",
+	}
+
+	if !source.Immutable() {
+		t.Error("Immutable() = false for a full sha, want true")
+	}
+
+	sourceIdentity, revision := source.Identity()
+	if sourceIdentity != source.URL || revision != source.Revision {
+		t.Errorf("Identity() = (%q, %q), want (%q, %q)", sourceIdentity, revision, source.URL, source.Revision)
+	}
+
+	branchSource := GitConfigSource{URL: source.URL, Revision: "master"}
+	if branchSource.Immutable() {
+		t.Error("Immutable() = true for a branch name, want false")
+	}
+}
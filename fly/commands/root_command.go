@@ -0,0 +1,15 @@
+package commands
+
+// RootCommand is fly's top-level command. jessevdk/go-flags expands each
+// field tagged with `command:"..."` into a subcommand, dispatching to its
+// Execute method.
+type RootCommand struct {
+	Target  string `short:"t" long:"target" description:"Concourse target name"`
+	Verbose bool   `short:"v" long:"verbose" description:"Print API requests and responses"`
+
+	TaskProvenance TaskProvenanceCommand `command:"task-provenance" description:"Print the task config provenance recorded for a finished build"`
+}
+
+// Fly is populated by the CLI flag parser in main() before any subcommand's
+// Execute is invoked.
+var Fly RootCommand
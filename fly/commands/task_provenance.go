@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/concourse/concourse/fly/rc"
+)
+
+// TaskProvenanceCommand prints, per task step, where its config came from
+// and the digest it was loaded from, as recorded on the given build's plan.
+type TaskProvenanceCommand struct {
+	Job   string `short:"j" long:"job"   required:"true" description:"Job to get provenance for, e.g. my-pipeline/my-job"`
+	Build string `short:"b" long:"build" required:"true" description:"Build number to get provenance for"`
+}
+
+func (command *TaskProvenanceCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	if err := target.Validate(); err != nil {
+		return err
+	}
+
+	pipelineName, jobName, err := splitPipelineJob(command.Job)
+	if err != nil {
+		return err
+	}
+
+	build, found, err := target.Team().JobBuild(pipelineName, jobName, command.Build)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("build %s/%s #%s not found", pipelineName, jobName, command.Build)
+	}
+
+	plan, found, err := target.Client().BuildPlan(build.ID)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("plan for build #%d is not available", build.ID)
+	}
+
+	provenance := plan.TaskConfigProvenance()
+	if len(provenance) == 0 {
+		fmt.Fprintln(os.Stderr, "no task config provenance recorded for this build")
+		return nil
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(table, "entrypoint\turi\tdigest")
+	for _, p := range provenance {
+		fmt.Fprintf(table, "%s\t%s\t%s\n", p.EntryPoint, p.URI, p.Digest)
+	}
+
+	return table.Flush()
+}
+
+func splitPipelineJob(job string) (string, string, error) {
+	segs := strings.SplitN(job, "/", 2)
+	if len(segs) != 2 {
+		return "", "", fmt.Errorf("argument '%s' is not in the form 'pipeline/job'", job)
+	}
+
+	return segs[0], segs[1], nil
+}
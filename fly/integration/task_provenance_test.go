@@ -0,0 +1,87 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/rata"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("task-provenance", func() {
+		var (
+			teamName     = "main"
+			pipelineName = "pipeline"
+			jobName      = "job"
+			buildID      = 123
+			buildPath    string
+			planPath     string
+			err          error
+		)
+
+		Context("make sure the command exists", func() {
+			It("calls the task-provenance command", func() {
+				flyCmd := exec.Command(flyPath, "task-provenance")
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+
+				Expect(err).ToNot(HaveOccurred())
+				Consistently(sess.Err).ShouldNot(gbytes.Say("error: Unknown command"))
+
+				<-sess.Exited
+			})
+		})
+
+		Context("when the job and build are specified", func() {
+			BeforeEach(func() {
+				buildPath, err = atc.Routes.CreatePathForRoute(atc.GetJobBuild, rata.Params{
+					"team_name":     teamName,
+					"pipeline_name": pipelineName,
+					"job_name":      jobName,
+					"build_name":    "42",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				planPath, err = atc.Routes.CreatePathForRoute(atc.BuildPlan, rata.Params{
+					"build_id": fmt.Sprintf("%d", buildID),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			JustBeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", buildPath),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{
+							ID:      buildID,
+							Name:    "42",
+							Status:  "succeeded",
+							JobName: jobName,
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", planPath),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PublicBuildPlan{}),
+					),
+				)
+			})
+
+			It("reports that no task config provenance is recorded when the build's plan has none", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "task-provenance", "-j", fmt.Sprintf("%s/%s", pipelineName, jobName), "-b", "42")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say("no task config provenance recorded for this build"))
+
+				<-sess.Exited
+			})
+		})
+	})
+})